@@ -1,10 +1,12 @@
 package obfuscation
 
 import (
+	"encoding/base64"
 	"math"
 	"regexp"
 	"strings"
 
+	"github.com/ossf/package-analysis/internal/staticanalysis/obfuscation/fpfilter"
 	"github.com/ossf/package-analysis/internal/staticanalysis/obfuscation/stats"
 	"github.com/ossf/package-analysis/internal/staticanalysis/obfuscation/stringentropy"
 	"github.com/ossf/package-analysis/internal/staticanalysis/token"
@@ -52,6 +54,16 @@ func characterAnalysis(symbols []string) (
 	return
 }
 
+// zxcvbnEntropySummary computes the distributional summary of
+// stringentropy.EstimateGuessEntropy over a collection of symbols.
+func zxcvbnEntropySummary(symbols []string) stats.SampleStatistics {
+	var entropies []float64
+	for _, s := range symbols {
+		entropies = append(entropies, stringentropy.EstimateGuessEntropy(s))
+	}
+	return stats.Summarise(entropies)
+}
+
 /*
 ComputeSignals creates a FileSignals object based on the data obtained from CollectData
 for a given file. These signals may be useful to determine whether the code is obfuscated.
@@ -59,13 +71,32 @@ for a given file. These signals may be useful to determine whether the code is o
 func ComputeSignals(rawData FileData) FileSignals {
 	signals := FileSignals{}
 
-	literals := utils.Transform(rawData.StringLiterals, func(s token.String) string { return s.Value })
+	allLiterals := utils.Transform(rawData.StringLiterals, func(s token.String) string { return s.Value })
+	literals, largeLiterals := partitionLiteralsBySize(allLiterals, MaxLiteralSizeBytes)
+
+	// Large literals are excluded from the entropy analysis below (see
+	// MaxLiteralSizeBytes), but the encoded-string detectors and
+	// DecodedPayloadAnalysis still need a chance at them: a base64/hex-wrapped
+	// payload is a realistic thing to find past the 2KiB mark. Scanning a
+	// bounded sample of each large literal, rather than the whole thing,
+	// keeps that analysis from reintroducing the unbounded cost that large
+	// literals are summarised to avoid.
+	var largeLiteralSamples []string
+	for _, large := range largeLiterals {
+		windows := sampleWindows(large, largeLiteralSampleWindows, largeLiteralWindowSize)
+		signals.LargeLiterals = append(signals.LargeLiterals, summariseLargeLiteral(large, windows))
+		largeLiteralSamples = append(largeLiteralSamples, windows...)
+	}
+	detectionLiterals := append(append([]string{}, literals...), largeLiteralSamples...)
+
 	signals.StringLengths, signals.StringEntropySummary, signals.CombinedStringEntropy =
 		characterAnalysis(literals)
+	signals.StringZxcvbnEntropy = zxcvbnEntropySummary(literals)
 
 	identifierNames := utils.Transform(rawData.Identifiers, func(i token.Identifier) string { return i.Name })
 	signals.IdentifierLengths, signals.IdentifierEntropySummary, signals.CombinedIdentifierEntropy =
 		characterAnalysis(identifierNames)
+	signals.IdentifierZxcvbnEntropy = zxcvbnEntropySummary(identifierNames)
 
 	signals.SuspiciousIdentifiers = map[string][]string{}
 	for ruleName, pattern := range suspiciousIdentifierPatterns {
@@ -78,27 +109,98 @@ func ComputeSignals(rawData FileData) FileSignals {
 	}
 
 	signals.Base64Strings = []string{}
-	for _, s := range literals {
+	var base64Candidates []encodedStringCandidate
+	for _, s := range detectionLiterals {
 		matches := longBase64String.FindAllString(s, -1)
 		for _, candidate := range matches {
 			// use some extra checks to reduce false positives
 			if digit.MatchString(candidate) && nonHexLetter.MatchString(candidate) {
-				signals.Base64Strings = append(signals.Base64Strings, matches...)
+				signals.Base64Strings = append(signals.Base64Strings, candidate)
+				if decoded, err := base64.StdEncoding.DecodeString(candidate); err == nil {
+					base64Candidates = append(base64Candidates, newEncodedStringCandidate("base64", candidate, decoded))
+				}
 			}
 		}
 	}
 
+	hexStrings, hexCandidates := detectHexStrings(detectionLiterals)
+	base32Strings, base32Candidates := detectBase32Strings(detectionLiterals)
+	urlStrings, urlCandidates := detectURLEncodedStrings(detectionLiterals)
+	unicodeEscapeStrings, _ := detectUnicodeEscapeStrings(detectionLiterals)
+	signals.HexStrings = hexStrings
+	signals.Base32Strings = base32Strings
+	signals.URLEncodedStrings = urlStrings
+	signals.UnicodeEscapeStrings = unicodeEscapeStrings
+
+	var allCandidates []encodedStringCandidate
+	allCandidates = append(allCandidates, base64Candidates...)
+	allCandidates = append(allCandidates, hexCandidates...)
+	allCandidates = append(allCandidates, base32Candidates...)
+	allCandidates = append(allCandidates, urlCandidates...)
+	signals.DecodedPayloads = DecodedPayloadAnalysis(allCandidates)
+
+	applyFalsePositiveFilter(&signals)
+
 	return signals
 }
 
+// applyFalsePositiveFilter removes candidates from SuspiciousIdentifiers and
+// the various encoded-string signals that fpfilter.Default classifies as
+// likely benign, moving them into signals.LikelyBenignStrings instead.
+func applyFalsePositiveFilter(signals *FileSignals) {
+	for ruleName, candidates := range signals.SuspiciousIdentifiers {
+		signals.SuspiciousIdentifiers[ruleName] = signals.filterBenign(candidates)
+	}
+	signals.Base64Strings = signals.filterBenign(signals.Base64Strings)
+
+	// Hex/base32/URL/unicode-escape candidates skip the digit-absence
+	// shortcut: an all-letter substring is common in these encodings and
+	// isn't on its own evidence of being benign, unlike for identifiers and
+	// base64 strings. Only an actual dictionary-word overlap counts.
+	signals.HexStrings = signals.filterBenignByDictionary(signals.HexStrings)
+	signals.Base32Strings = signals.filterBenignByDictionary(signals.Base32Strings)
+	signals.URLEncodedStrings = signals.filterBenignByDictionary(signals.URLEncodedStrings)
+	signals.UnicodeEscapeStrings = signals.filterBenignByDictionary(signals.UnicodeEscapeStrings)
+}
+
+// filterBenign splits candidates into those fpfilter.Default considers
+// suspicious (returned, kept in the caller's signal) and those it considers
+// likely benign (appended to signals.LikelyBenignStrings), using the
+// digit-absence-or-dictionary-overlap rule appropriate for identifiers and
+// base64 strings.
+func (signals *FileSignals) filterBenign(candidates []string) []string {
+	return signals.filterBy(candidates, fpfilter.Default.IsLikelyBenign)
+}
+
+// filterBenignByDictionary is like filterBenign but only treats a candidate
+// as benign when it overlaps the dictionary, without the digit-absence
+// shortcut; see applyFalsePositiveFilter.
+func (signals *FileSignals) filterBenignByDictionary(candidates []string) []string {
+	return signals.filterBy(candidates, fpfilter.Default.HasDictionaryOverlap)
+}
+
+func (signals *FileSignals) filterBy(candidates []string, isBenign func(string) bool) []string {
+	kept := []string{}
+	for _, c := range candidates {
+		if isBenign(c) {
+			signals.LikelyBenignStrings = append(signals.LikelyBenignStrings, c)
+		} else {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
 func NoSignals() FileSignals {
 	return FileSignals{
 		StringLengths:             map[int]int{},
 		StringEntropySummary:      stats.NoData(),
 		CombinedStringEntropy:     math.NaN(),
+		StringZxcvbnEntropy:       stats.NoData(),
 		IdentifierLengths:         map[int]int{},
 		IdentifierEntropySummary:  stats.NoData(),
 		CombinedIdentifierEntropy: math.NaN(),
+		IdentifierZxcvbnEntropy:   stats.NoData(),
 	}
 }
 
@@ -106,6 +208,8 @@ func NoSignals() FileSignals {
 func RemoveNaNs(s *FileSignals) {
 	s.StringEntropySummary = s.StringEntropySummary.ReplaceNaNs(0)
 	s.IdentifierEntropySummary = s.IdentifierEntropySummary.ReplaceNaNs(0)
+	s.StringZxcvbnEntropy = s.StringZxcvbnEntropy.ReplaceNaNs(0)
+	s.IdentifierZxcvbnEntropy = s.IdentifierZxcvbnEntropy.ReplaceNaNs(0)
 
 	if math.IsNaN(s.CombinedStringEntropy) {
 		s.CombinedStringEntropy = 0.0
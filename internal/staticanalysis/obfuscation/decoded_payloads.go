@@ -0,0 +1,144 @@
+package obfuscation
+
+import (
+	"regexp"
+
+	"github.com/ossf/package-analysis/internal/staticanalysis/obfuscation/stringentropy"
+)
+
+// maxDecodeRecursionDepth bounds how many times DecodedPayloadAnalysis will
+// decode a payload that itself looks like another encoded layer.
+const maxDecodeRecursionDepth = 3
+
+// suspiciousAPIPatterns matches these keywords as whole words only, so
+// decoded plaintext like "this field is required" or "an important update"
+// doesn't trigger on "require"/"import" as mere substrings.
+var suspiciousAPIPatterns = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"eval", regexp.MustCompile(`\beval\b`)},
+	{"exec", regexp.MustCompile(`\bexec\b`)},
+	{"require", regexp.MustCompile(`\brequire\b`)},
+	{"import", regexp.MustCompile(`\bimport\b`)},
+	{"__import__", regexp.MustCompile(`\b__import__\b`)},
+	{"child_process", regexp.MustCompile(`\bchild_process\b`)},
+}
+
+var codePunctuationPattern = regexp.MustCompile(`[{}();]`)
+
+// DecodedPayload is the result of decoding and inspecting a single encoded
+// layer, possibly several levels deep inside another encoding.
+type DecodedPayload struct {
+	Encoding       string
+	DecodedLength  int
+	DecodedEntropy float64
+	LooksLikeCode  bool
+	SuspiciousAPIs []string
+	RecursionDepth int
+}
+
+// DecodedPayloadAnalysis decodes each detected encoded-string candidate and
+// inspects the decoded bytes for signs of source code or further encoding,
+// recursing into nested payloads up to maxDecodeRecursionDepth.
+func DecodedPayloadAnalysis(candidates []encodedStringCandidate) []DecodedPayload {
+	var results []DecodedPayload
+	for _, c := range candidates {
+		results = append(results, analyseDecodedPayload(c.Encoding, c.Decoded, 0)...)
+	}
+	return results
+}
+
+func analyseDecodedPayload(encoding string, decoded []byte, depth int) []DecodedPayload {
+	results := []DecodedPayload{{
+		Encoding:       encoding,
+		DecodedLength:  len(decoded),
+		DecodedEntropy: stringentropy.CalculateEntropy(string(decoded), nil),
+		LooksLikeCode:  looksLikeCode(decoded),
+		SuspiciousAPIs: findSuspiciousAPIs(decoded),
+		RecursionDepth: depth,
+	}}
+
+	if depth >= maxDecodeRecursionDepth {
+		return results
+	}
+	if hasGzipMagic(decoded) || hasZlibMagic(decoded) {
+		// compressed payload; decompression is out of scope for this pass
+		return results
+	}
+	if nested, nestedEncoding, ok := decodeNestedCandidate(string(decoded)); ok {
+		results = append(results, analyseDecodedPayload(nestedEncoding, nested, depth+1)...)
+	}
+	return results
+}
+
+func hasGzipMagic(b []byte) bool {
+	return len(b) >= 2 && b[0] == 0x1f && b[1] == 0x8b
+}
+
+func hasZlibMagic(b []byte) bool {
+	if len(b) < 2 || b[0] != 0x78 {
+		return false
+	}
+	switch b[1] {
+	case 0x01, 0x5e, 0x9c, 0xda:
+		return true
+	default:
+		return false
+	}
+}
+
+// looksLikeCode is a heuristic for whether decoded bytes are source code:
+// mostly printable, balanced brackets, and containing either a suspicious API
+// call or code punctuation ({, }, (, ) or ;).
+func looksLikeCode(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+
+	printable := 0
+	for _, b := range data {
+		if (b >= 32 && b < 127) || b == '\n' || b == '\t' {
+			printable++
+		}
+	}
+	if float64(printable)/float64(len(data)) < 0.85 {
+		return false
+	}
+
+	text := string(data)
+	if !bracketsBalanced(text) {
+		return false
+	}
+	if len(findSuspiciousAPIs(data)) > 0 {
+		return true
+	}
+	return codePunctuationPattern.MatchString(text)
+}
+
+func bracketsBalanced(s string) bool {
+	depth := 0
+	for _, r := range s {
+		switch r {
+		case '(', '{', '[':
+			depth++
+		case ')', '}', ']':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+func findSuspiciousAPIs(data []byte) []string {
+	text := string(data)
+	var found []string
+	for _, p := range suspiciousAPIPatterns {
+		if p.pattern.MatchString(text) {
+			found = append(found, p.name)
+		}
+	}
+	return found
+}
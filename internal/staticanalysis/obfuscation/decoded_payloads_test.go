@@ -0,0 +1,92 @@
+package obfuscation
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestDecodedPayloadAnalysisSingleLayer(t *testing.T) {
+	plain := []byte("just some plain decoded bytes, nothing nested here")
+	results := DecodedPayloadAnalysis([]encodedStringCandidate{
+		{Encoding: "base64", Raw: "irrelevant", Decoded: plain},
+	})
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result for a non-nested payload, got %d", len(results))
+	}
+	if results[0].RecursionDepth != 0 {
+		t.Errorf("expected RecursionDepth 0, got %d", results[0].RecursionDepth)
+	}
+	if results[0].DecodedLength != len(plain) {
+		t.Errorf("DecodedLength = %d, want %d", results[0].DecodedLength, len(plain))
+	}
+}
+
+func TestDecodedPayloadAnalysisRecursionTerminates(t *testing.T) {
+	// Build a chain of base64 layers several levels deeper than
+	// maxDecodeRecursionDepth, and confirm recursion stops at the bound
+	// rather than decoding forever.
+	layer := []byte("eval(\"still dangerous\")")
+	const totalLayers = maxDecodeRecursionDepth + 3
+	for i := 0; i < totalLayers; i++ {
+		encoded := base64.StdEncoding.EncodeToString(layer)
+		layer = []byte(encoded)
+	}
+
+	// The outermost layer is the literal; candidates carry the result of
+	// decoding it once, matching what detectHexStrings/detectBase32Strings/
+	// the base64 loop in ComputeSignals would have already done.
+	firstDecoded, err := base64.StdEncoding.DecodeString(string(layer))
+	if err != nil {
+		t.Fatalf("setup: failed to decode outer layer: %v", err)
+	}
+
+	results := DecodedPayloadAnalysis([]encodedStringCandidate{
+		{Encoding: "base64", Raw: string(layer), Decoded: firstDecoded},
+	})
+
+	if len(results) != maxDecodeRecursionDepth+1 {
+		t.Fatalf("expected recursion to stop after %d levels (got %d results)",
+			maxDecodeRecursionDepth+1, len(results))
+	}
+	for i, r := range results {
+		if r.RecursionDepth != i {
+			t.Errorf("results[%d].RecursionDepth = %d, want %d", i, r.RecursionDepth, i)
+		}
+	}
+	if results[len(results)-1].RecursionDepth != maxDecodeRecursionDepth {
+		t.Errorf("deepest result RecursionDepth = %d, want %d",
+			results[len(results)-1].RecursionDepth, maxDecodeRecursionDepth)
+	}
+}
+
+func TestDecodedPayloadAnalysisDetectsSuspiciousAPI(t *testing.T) {
+	results := DecodedPayloadAnalysis([]encodedStringCandidate{
+		{Encoding: "hex", Raw: "irrelevant", Decoded: []byte(`eval(require('child_process').exec(cmd))`)},
+	})
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if !results[0].LooksLikeCode {
+		t.Error("expected LooksLikeCode to be true for a snippet with balanced brackets and eval/require calls")
+	}
+	if len(results[0].SuspiciousAPIs) == 0 {
+		t.Error("expected SuspiciousAPIs to be non-empty")
+	}
+}
+
+func TestDecodedPayloadAnalysisIgnoresPlainEnglishFalsePositive(t *testing.T) {
+	// Regression test: "required" and "important" must not trigger on
+	// "require"/"import" as mere substrings (see findSuspiciousAPIs).
+	results := DecodedPayloadAnalysis([]encodedStringCandidate{
+		{Encoding: "base64", Raw: "irrelevant", Decoded: []byte("this field is required for an important update")},
+	})
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if len(results[0].SuspiciousAPIs) != 0 {
+		t.Errorf("expected no suspicious APIs in plain English text, got %v", results[0].SuspiciousAPIs)
+	}
+}
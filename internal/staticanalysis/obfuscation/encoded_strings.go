@@ -0,0 +1,149 @@
+package obfuscation
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/ossf/package-analysis/internal/staticanalysis/obfuscation/stringentropy"
+)
+
+// encodedStringCandidate is a literal substring detected as plausibly holding
+// data in some non-plaintext encoding, shared across all the detectors below
+// so DecodedPayloadAnalysis can reason uniformly about the decoded payload.
+type encodedStringCandidate struct {
+	Encoding       string
+	Raw            string
+	Decoded        []byte
+	DecodedEntropy float64
+}
+
+var (
+	longHexString     = regexp.MustCompile(`[0-9A-Fa-f]{32,}`)
+	longBase32String  = regexp.MustCompile(`[A-Z2-7]{16,}={0,6}`)
+	urlEncodedRun     = regexp.MustCompile(`(?:%[0-9A-Fa-f]{2}){3,}`)
+	unicodeEscapeRun  = regexp.MustCompile(`(?:\\x[0-9A-Fa-f]{2}|\\u[0-9A-Fa-f]{4}){4,}`)
+	unicodeEscapeItem = regexp.MustCompile(`\\x([0-9A-Fa-f]{2})|\\u([0-9A-Fa-f]{4})`)
+)
+
+func newEncodedStringCandidate(encoding, raw string, decoded []byte) encodedStringCandidate {
+	return encodedStringCandidate{
+		Encoding:       encoding,
+		Raw:            raw,
+		Decoded:        decoded,
+		DecodedEntropy: stringentropy.CalculateEntropy(string(decoded), nil),
+	}
+}
+
+// detectHexStrings finds long contiguous hex strings in literals. Matches
+// made up entirely of hex letters (a-f) with no digit are rejected, since
+// those tend to be ordinary words rather than encoded data (e.g. "deafbeef").
+func detectHexStrings(literals []string) ([]string, []encodedStringCandidate) {
+	var found []string
+	var candidates []encodedStringCandidate
+	for _, s := range literals {
+		for _, m := range longHexString.FindAllString(s, -1) {
+			if !digit.MatchString(m) {
+				continue
+			}
+			trimmed := m
+			if len(trimmed)%2 != 0 {
+				trimmed = trimmed[:len(trimmed)-1]
+			}
+			decoded, err := hex.DecodeString(trimmed)
+			if err != nil {
+				continue
+			}
+			found = append(found, m)
+			candidates = append(candidates, newEncodedStringCandidate("hex", m, decoded))
+		}
+	}
+	return found, candidates
+}
+
+// detectBase32Strings finds RFC4648 base32 strings in literals.
+func detectBase32Strings(literals []string) ([]string, []encodedStringCandidate) {
+	var found []string
+	var candidates []encodedStringCandidate
+	for _, s := range literals {
+		for _, m := range longBase32String.FindAllString(s, -1) {
+			decoded, err := base32.StdEncoding.DecodeString(m)
+			if err != nil {
+				continue
+			}
+			found = append(found, m)
+			candidates = append(candidates, newEncodedStringCandidate("base32", m, decoded))
+		}
+	}
+	return found, candidates
+}
+
+// detectURLEncodedStrings finds runs of URL percent-encoding in literals.
+func detectURLEncodedStrings(literals []string) ([]string, []encodedStringCandidate) {
+	var found []string
+	var candidates []encodedStringCandidate
+	for _, s := range literals {
+		for _, m := range urlEncodedRun.FindAllString(s, -1) {
+			decoded, err := url.QueryUnescape(m)
+			if err != nil {
+				continue
+			}
+			found = append(found, m)
+			candidates = append(candidates, newEncodedStringCandidate("url", m, []byte(decoded)))
+		}
+	}
+	return found, candidates
+}
+
+// detectUnicodeEscapeStrings finds literals heavy with \xNN / \uNNNN escapes.
+func detectUnicodeEscapeStrings(literals []string) ([]string, []encodedStringCandidate) {
+	var found []string
+	var candidates []encodedStringCandidate
+	for _, s := range literals {
+		for _, m := range unicodeEscapeRun.FindAllString(s, -1) {
+			decoded := decodeUnicodeEscapes(m)
+			found = append(found, m)
+			candidates = append(candidates, newEncodedStringCandidate("unicode-escape", m, decoded))
+		}
+	}
+	return found, candidates
+}
+
+func decodeUnicodeEscapes(s string) []byte {
+	var decoded []byte
+	for _, m := range unicodeEscapeItem.FindAllStringSubmatch(s, -1) {
+		if m[1] != "" {
+			if b, err := hex.DecodeString(m[1]); err == nil {
+				decoded = append(decoded, b...)
+			}
+		} else if m[2] != "" {
+			if n, err := strconv.ParseInt(m[2], 16, 32); err == nil {
+				decoded = append(decoded, []byte(string(rune(n)))...)
+			}
+		}
+	}
+	return decoded
+}
+
+// decodeNestedCandidate looks for a further base64 or hex payload inside s,
+// used by DecodedPayloadAnalysis to recurse into layered encodings.
+func decodeNestedCandidate(s string) (decoded []byte, encoding string, ok bool) {
+	if m := longBase64String.FindString(s); m != "" {
+		if d, err := base64.StdEncoding.DecodeString(m); err == nil {
+			return d, "base64", true
+		}
+	}
+	if m := longHexString.FindString(s); m != "" {
+		trimmed := m
+		if len(trimmed)%2 != 0 {
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+		if d, err := hex.DecodeString(trimmed); err == nil {
+			return d, "hex", true
+		}
+	}
+	return nil, "", false
+}
@@ -0,0 +1,66 @@
+package obfuscation
+
+import (
+	"encoding/base32"
+	"testing"
+)
+
+func TestDetectHexStrings(t *testing.T) {
+	withDigit := "0123456789abcdef0123456789abcdef" // 32 hex chars, contains digits
+	pureWord := "cafebabedeadbeeffeedfacefacefeed"  // 32 hex-letter chars, no digit
+
+	found, candidates := detectHexStrings([]string{withDigit, pureWord, "short"})
+
+	if len(found) != 1 || found[0] != withDigit {
+		t.Errorf("detectHexStrings found = %v, want only %q", found, withDigit)
+	}
+	if len(candidates) != 1 || candidates[0].Encoding != "hex" {
+		t.Fatalf("expected exactly 1 hex candidate, got %v", candidates)
+	}
+	if len(candidates[0].Decoded) == 0 {
+		t.Error("expected non-empty decoded bytes for valid hex candidate")
+	}
+}
+
+func TestDetectBase32Strings(t *testing.T) {
+	plaintext := "hello world test payload"
+	encoded := base32.StdEncoding.EncodeToString([]byte(plaintext))
+
+	found, candidates := detectBase32Strings([]string{encoded, "short"})
+
+	if len(found) != 1 || found[0] != encoded {
+		t.Errorf("detectBase32Strings found = %v, want only %q", found, encoded)
+	}
+	if len(candidates) != 1 {
+		t.Fatalf("expected exactly 1 base32 candidate, got %d", len(candidates))
+	}
+	if string(candidates[0].Decoded) != plaintext {
+		t.Errorf("decoded = %q, want %q", candidates[0].Decoded, plaintext)
+	}
+}
+
+func TestDetectURLEncodedStrings(t *testing.T) {
+	literal := "prefix %20%2F%3D suffix"
+
+	found, candidates := detectURLEncodedStrings([]string{literal})
+
+	if len(found) != 1 || found[0] != "%20%2F%3D" {
+		t.Errorf("detectURLEncodedStrings found = %v, want [%q]", found, "%20%2F%3D")
+	}
+	if len(candidates) != 1 || string(candidates[0].Decoded) != " /=" {
+		t.Errorf("decoded = %q, want %q", candidates[0].Decoded, " /=")
+	}
+}
+
+func TestDetectUnicodeEscapeStrings(t *testing.T) {
+	literal := `\x48\x65\x6c\x6c\x6f`
+
+	found, candidates := detectUnicodeEscapeStrings([]string{literal})
+
+	if len(found) != 1 {
+		t.Fatalf("expected 1 match, got %v", found)
+	}
+	if len(candidates) != 1 || string(candidates[0].Decoded) != "Hello" {
+		t.Errorf("decoded = %q, want %q", candidates[0].Decoded, "Hello")
+	}
+}
@@ -0,0 +1,108 @@
+package fpfilter
+
+import "strings"
+
+// ahoCorasickNode is a single state in the automaton's trie.
+type ahoCorasickNode struct {
+	children map[byte]*ahoCorasickNode
+	fail     *ahoCorasickNode
+
+	// isWordEnd is true if this node marks the end of a registered pattern.
+	isWordEnd bool
+	// hasOutputInChain is true if this node or any node reachable by following
+	// fail links marks the end of a registered pattern. Precomputing this
+	// during construction keeps matching a single O(1)-per-character lookup.
+	hasOutputInChain bool
+}
+
+// ahoCorasick is a multi-pattern substring matcher built once over a fixed
+// set of patterns, then reused to scan many candidate strings in O(n) time
+// each (n = length of the candidate).
+type ahoCorasick struct {
+	root *ahoCorasickNode
+}
+
+// newAhoCorasick builds an automaton matching the given patterns, lowercased,
+// skipping any pattern shorter than minLength to avoid trivial matches.
+func newAhoCorasick(patterns []string, minLength int) *ahoCorasick {
+	root := newAhoCorasickNode()
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if len(p) < minLength {
+			continue
+		}
+		node := root
+		for i := 0; i < len(p); i++ {
+			c := p[i]
+			next, ok := node.children[c]
+			if !ok {
+				next = newAhoCorasickNode()
+				node.children[c] = next
+			}
+			node = next
+		}
+		node.isWordEnd = true
+	}
+
+	buildFailureLinks(root)
+	return &ahoCorasick{root: root}
+}
+
+func newAhoCorasickNode() *ahoCorasickNode {
+	return &ahoCorasickNode{children: map[byte]*ahoCorasickNode{}}
+}
+
+// buildFailureLinks computes the fail link and hasOutputInChain flag for every
+// node via a breadth-first traversal, following the standard Aho-Corasick
+// automaton construction.
+func buildFailureLinks(root *ahoCorasickNode) {
+	queue := make([]*ahoCorasickNode, 0, len(root.children))
+	for _, child := range root.children {
+		child.fail = root
+		child.hasOutputInChain = child.isWordEnd
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for c, child := range node.children {
+			queue = append(queue, child)
+
+			failNode := node.fail
+			for failNode != nil {
+				if next, ok := failNode.children[c]; ok {
+					child.fail = next
+					break
+				}
+				failNode = failNode.fail
+			}
+			if child.fail == nil {
+				child.fail = root
+			}
+			child.hasOutputInChain = child.isWordEnd || child.fail.hasOutputInChain
+		}
+	}
+}
+
+// ContainsAny reports whether text contains any registered pattern as a substring.
+func (a *ahoCorasick) ContainsAny(text string) bool {
+	node := a.root
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		for node != a.root {
+			if _, ok := node.children[c]; ok {
+				break
+			}
+			node = node.fail
+		}
+		if next, ok := node.children[c]; ok {
+			node = next
+		}
+		if node.hasOutputInChain {
+			return true
+		}
+	}
+	return false
+}
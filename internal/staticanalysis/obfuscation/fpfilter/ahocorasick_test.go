@@ -0,0 +1,47 @@
+package fpfilter
+
+import "testing"
+
+func TestAhoCorasickContainsAny(t *testing.T) {
+	matcher := newAhoCorasick([]string{"hello", "world", "test", "is"}, 4)
+
+	cases := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{"exact match", "hello", true},
+		{"substring match", "xhelloy", true},
+		{"match at end", "theworld", true},
+		{"no match", "abcdefg", false},
+		{"empty text", "", false},
+		{"pattern shorter than minLength is not registered", "thisisit", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := matcher.ContainsAny(c.text); got != c.want {
+				t.Errorf("ContainsAny(%q) = %v, want %v", c.text, got, c.want)
+			}
+		})
+	}
+}
+
+func TestAhoCorasickOverlappingPatterns(t *testing.T) {
+	// "test" is a suffix of "fastest". With the overlapping pattern
+	// "fastesty" also registered, scanning "fastest" ends on the non-final
+	// "fastest" trie node, so "test" is only found via that node's fail-link
+	// chain, not a prefix match from the root - this exercises
+	// hasOutputInChain construction.
+	matcher := newAhoCorasick([]string{"fastesty", "test"}, 4)
+	if !matcher.ContainsAny("fastest") {
+		t.Error("expected ContainsAny(\"fastest\") to find \"test\" via fail links")
+	}
+}
+
+func TestAhoCorasickEmptyPatternList(t *testing.T) {
+	matcher := newAhoCorasick(nil, 4)
+	if matcher.ContainsAny("anything") {
+		t.Error("expected no matches with an empty pattern list")
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Package fpfilter reduces false positives in obfuscation signals (suspicious
+identifiers, base64-looking strings, etc.) by recognising candidates that
+overlap with common English/programming vocabulary, or that are missing the
+digit a genuinely-obfuscated token would typically contain.
+*/
+package fpfilter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// minWordLength is the shortest dictionary substring that counts as a match;
+// below this, common short words like "is" or "an" would match almost anything.
+const minWordLength = 4
+
+var hasDigit = regexp.MustCompile(`\d`)
+
+// FalsePositiveFilter classifies candidate identifiers/strings as likely
+// benign based on dictionary overlap and digit presence.
+type FalsePositiveFilter struct {
+	matcher   *ahoCorasick
+	wordCheck bool
+}
+
+// New builds a FalsePositiveFilter over the embedded word lists (common
+// English words, programming-book tokens and a curated benign-substring
+// badlist), plus any extraWordLists supplied by the caller. wordCheck toggles
+// whether dictionary-substring matching is applied at all; when false, only
+// the digit-presence check is used.
+func New(wordCheck bool, extraWordLists ...[]string) *FalsePositiveFilter {
+	words := make([]string, 0, len(englishWords)+len(programmingTerms)+len(badlistWords))
+	words = append(words, englishWords...)
+	words = append(words, programmingTerms...)
+	words = append(words, badlistWords...)
+	for _, list := range extraWordLists {
+		words = append(words, list...)
+	}
+
+	return &FalsePositiveFilter{
+		matcher:   newAhoCorasick(words, minWordLength),
+		wordCheck: wordCheck,
+	}
+}
+
+// Default is the package-wide filter built once at init time from the
+// embedded word lists, for callers that don't need custom lists.
+var Default = New(true)
+
+// IsLikelyBenign reports whether candidate is probably a false positive:
+// either it lacks a digit (real obfuscated identifiers/payloads almost always
+// have one), or it contains a sufficiently long dictionary substring.
+//
+// The digit-absence shortcut is only appropriate for identifiers and
+// base64-looking strings, where a genuine obfuscated token overwhelmingly
+// contains a digit; it is not a safe signal for other encodings (e.g. a
+// malicious base32/hex payload can easily land on an all-letter substring).
+// Callers screening those should use HasDictionaryOverlap instead.
+func (f *FalsePositiveFilter) IsLikelyBenign(candidate string) bool {
+	if !hasDigit.MatchString(candidate) {
+		return true
+	}
+	return f.HasDictionaryOverlap(candidate)
+}
+
+// HasDictionaryOverlap reports whether candidate contains a sufficiently
+// long dictionary substring, without the digit-absence shortcut.
+func (f *FalsePositiveFilter) HasDictionaryOverlap(candidate string) bool {
+	return f.wordCheck && f.matcher.ContainsAny(strings.ToLower(candidate))
+}
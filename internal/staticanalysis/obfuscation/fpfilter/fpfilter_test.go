@@ -0,0 +1,43 @@
+package fpfilter
+
+import "testing"
+
+func TestIsLikelyBenign(t *testing.T) {
+	f := New(true)
+
+	cases := []struct {
+		name      string
+		candidate string
+		want      bool
+	}{
+		{"no digit is benign regardless of content", "getUserName", true},
+		{"digit present but overlaps badlist word", "example1", true},
+		{"digit present, no dictionary overlap", "_0x8f3c91", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := f.IsLikelyBenign(c.candidate); got != c.want {
+				t.Errorf("IsLikelyBenign(%q) = %v, want %v", c.candidate, got, c.want)
+			}
+		})
+	}
+}
+
+func TestHasDictionaryOverlap(t *testing.T) {
+	f := New(true)
+
+	if !f.HasDictionaryOverlap("example1") {
+		t.Error(`expected HasDictionaryOverlap("example1") to be true (overlaps badlist word "example")`)
+	}
+	if f.HasDictionaryOverlap("8f3c91zz") {
+		t.Error(`expected HasDictionaryOverlap("8f3c91zz") to be false: digit presence alone must not count`)
+	}
+}
+
+func TestHasDictionaryOverlapWordCheckDisabled(t *testing.T) {
+	f := New(false)
+	if f.HasDictionaryOverlap("example1") {
+		t.Error("expected dictionary overlap to be disabled when wordCheck is false")
+	}
+}
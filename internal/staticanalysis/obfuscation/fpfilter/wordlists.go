@@ -0,0 +1,45 @@
+package fpfilter
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// Word lists are embedded at build time so the Aho-Corasick automaton in
+// Default can be built once at package init, keeping per-candidate scanning
+// cheap regardless of how the lists are sourced or updated.
+var (
+	//go:embed data/english_words.txt
+	englishWordsRaw string
+	//go:embed data/programming_terms.txt
+	programmingTermsRaw string
+	//go:embed data/badlist.txt
+	badlistRaw string
+)
+
+var (
+	englishWords     = SplitLines(englishWordsRaw)
+	programmingTerms = SplitLines(programmingTermsRaw)
+	badlistWords     = SplitLines(badlistRaw)
+)
+
+// EnglishWords returns the embedded common-English wordlist, for reuse by
+// other packages (e.g. stringentropy's dictionary-based entropy estimator).
+func EnglishWords() []string {
+	return englishWords
+}
+
+// SplitLines splits raw into non-empty, trimmed lines, as used for the
+// embedded word lists above; exported for reuse by related word-list-based
+// packages such as stringentropy.
+func SplitLines(raw string) []string {
+	rawLines := strings.Split(strings.TrimSpace(raw), "\n")
+	lines := make([]string, 0, len(rawLines))
+	for _, l := range rawLines {
+		l = strings.TrimSpace(l)
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,131 @@
+package obfuscation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/rand"
+	"strings"
+
+	"github.com/ossf/package-analysis/internal/staticanalysis/obfuscation/stringentropy"
+)
+
+// MaxLiteralSizeBytes is the default size above which a string literal is
+// excluded from the normal entropy analysis and only summarised via
+// LargeLiteralSummary. It is a var, not a const, so callers that need a
+// different threshold can override it before calling ComputeSignals.
+// Without this, a single multi-MB generated/asset literal dominates the
+// distributional string stats and makes analysis time unbounded.
+var MaxLiteralSizeBytes = 2 * 1024
+
+const (
+	largeLiteralSampleWindows = 8
+	largeLiteralWindowSize    = 256
+)
+
+// LargeLiteralSummary summarises a literal too large to include in the
+// normal entropy analysis. The encoded-string detectors and
+// DecodedPayloadAnalysis still run against it, but only over the same
+// sampleWindows used here, keeping the cost of a single giant literal
+// bounded regardless of its actual length.
+type LargeLiteralSummary struct {
+	Length         int
+	SampledEntropy float64
+	EncodingClass  string
+	SHA256         string
+}
+
+// partitionLiteralsBySize splits literals into those at or below maxSize,
+// which are analysed in full, and those above it, which are only summarised.
+func partitionLiteralsBySize(literals []string, maxSize int) (small, large []string) {
+	for _, s := range literals {
+		if len(s) > maxSize {
+			large = append(large, s)
+		} else {
+			small = append(small, s)
+		}
+	}
+	return small, large
+}
+
+// sampleWindows bounds the cost of analysing a potentially huge literal by
+// returning a handful of fixed-size windows rather than the whole thing: s
+// itself if it already fits in one window, otherwise `windows` randomly
+// chosen windowSize-byte windows. Returning the windows separately, rather
+// than concatenated, lets callers run substring detectors over each window
+// without manufacturing a match that spans a seam between two windows that
+// weren't actually adjacent in s.
+func sampleWindows(s string, windows, windowSize int) []string {
+	if len(s) <= windowSize {
+		return []string{s}
+	}
+
+	out := make([]string, windows)
+	for i := 0; i < windows; i++ {
+		start := rand.Intn(len(s) - windowSize)
+		out[i] = s[start : start+windowSize]
+	}
+	return out
+}
+
+func sampledEntropy(windows []string) float64 {
+	return stringentropy.CalculateEntropy(strings.Join(windows, ""), nil)
+}
+
+func summariseLargeLiteral(s string, windows []string) LargeLiteralSummary {
+	sum := sha256.Sum256([]byte(s))
+	return LargeLiteralSummary{
+		Length:         len(s),
+		SampledEntropy: sampledEntropy(windows),
+		EncodingClass:  classifyEncoding(windows),
+		SHA256:         hex.EncodeToString(sum[:]),
+	}
+}
+
+func classifyEncoding(windows []string) string {
+	sample := strings.Join(windows, "")
+
+	// Checked in order from most to least specific: every hex string is also
+	// a valid (if incidental) base64 string, since the hex alphabet is a
+	// subset of the base64 one, so hex is checked first; otherwise a hex run
+	// that sits inside a longer base64-alphabet run would be classed base64.
+	switch {
+	case isMostlyHex(sample):
+		return "hex"
+	case isMostlyBase64(sample):
+		return "base64"
+	case isMostlyPrintable(sample):
+		return "text"
+	default:
+		return "binary"
+	}
+}
+
+// isMostlyHex requires the same digit-presence check detectHexStrings uses:
+// without it, a long run of only a-f letters (plain text that happens to run
+// 32+ chars, or a repeated low-entropy letter) would match longHexString and
+// get misclassified as hex.
+func isMostlyHex(sample string) bool {
+	m := longHexString.FindString(sample)
+	return len(m) > len(sample)/2 && digit.MatchString(m)
+}
+
+// isMostlyBase64 mirrors the digit/non-hex-letter check ComputeSignals
+// applies to base64 candidates: the base64 alphabet is a superset of the hex
+// one, so without this a long run of only a-f letters would match here too.
+func isMostlyBase64(sample string) bool {
+	m := longBase64String.FindString(sample)
+	return len(m) > len(sample)/2 && digit.MatchString(m) && nonHexLetter.MatchString(m)
+}
+
+func isMostlyPrintable(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	printable := 0
+	for _, r := range s {
+		if r >= 32 && r < 127 {
+			printable++
+		}
+	}
+	return float64(printable)/float64(len(s)) > 0.9
+}
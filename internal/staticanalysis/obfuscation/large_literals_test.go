@@ -0,0 +1,98 @@
+package obfuscation
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestPartitionLiteralsBySizeBoundary(t *testing.T) {
+	atLimit := strings.Repeat("a", MaxLiteralSizeBytes)
+	overLimit := strings.Repeat("a", MaxLiteralSizeBytes+1)
+
+	small, large := partitionLiteralsBySize([]string{atLimit, overLimit}, MaxLiteralSizeBytes)
+
+	if len(small) != 1 || small[0] != atLimit {
+		t.Errorf("expected literal of exactly MaxLiteralSizeBytes to be treated as small, got small=%v", small)
+	}
+	if len(large) != 1 || large[0] != overLimit {
+		t.Errorf("expected literal over MaxLiteralSizeBytes to be treated as large, got large=%v", large)
+	}
+}
+
+func TestSampleWindowsSmallInput(t *testing.T) {
+	s := strings.Repeat("b", largeLiteralWindowSize)
+
+	windows := sampleWindows(s, largeLiteralSampleWindows, largeLiteralWindowSize)
+
+	if len(windows) != 1 || windows[0] != s {
+		t.Errorf("expected a string no larger than one window to be returned unchanged, got %v", windows)
+	}
+}
+
+func TestSampleWindowsLargeInput(t *testing.T) {
+	s := strings.Repeat("c", largeLiteralWindowSize*largeLiteralSampleWindows*4)
+
+	windows := sampleWindows(s, largeLiteralSampleWindows, largeLiteralWindowSize)
+
+	if len(windows) != largeLiteralSampleWindows {
+		t.Fatalf("expected %d windows, got %d", largeLiteralSampleWindows, len(windows))
+	}
+	for _, w := range windows {
+		if len(w) != largeLiteralWindowSize {
+			t.Errorf("expected window of length %d, got %d", largeLiteralWindowSize, len(w))
+		}
+		if !strings.Contains(s, w) {
+			t.Errorf("window %q is not a substring of the original literal", w)
+		}
+	}
+}
+
+func TestClassifyEncoding(t *testing.T) {
+	// RawStdEncoding avoids '=' padding chars, which would otherwise break up
+	// a repeated encoding into several shorter matches instead of one long
+	// one; hashing gives a realistic mix of digits and non-hex letters, which
+	// classifyEncoding now requires to avoid misclassifying a plain a-f run.
+	sum := sha256.Sum256([]byte("large literal test payload"))
+	base64Sample := strings.Repeat(base64.RawStdEncoding.EncodeToString(sum[:]), 3)
+	hexSample := strings.Repeat("0123456789abcdef", 4)
+	textSample := strings.Repeat("the quick brown fox jumps over the lazy dog. ", 4)
+	binarySample := string([]byte{0x00, 0x01, 0x02, 0xff, 0xfe, 0x10, 0x20, 0x7f, 0x80, 0x90})
+
+	cases := []struct {
+		name   string
+		sample string
+		want   string
+	}{
+		{"base64", base64Sample, "base64"},
+		{"hex", hexSample, "hex"},
+		{"text", textSample, "text"},
+		{"binary", binarySample, "binary"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := classifyEncoding([]string{c.sample}); got != c.want {
+				t.Errorf("classifyEncoding(%q) = %q, want %q", c.sample, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSummariseLargeLiteral(t *testing.T) {
+	s := strings.Repeat("d", largeLiteralWindowSize*largeLiteralSampleWindows*2)
+	windows := sampleWindows(s, largeLiteralSampleWindows, largeLiteralWindowSize)
+
+	summary := summariseLargeLiteral(s, windows)
+
+	if summary.Length != len(s) {
+		t.Errorf("Length = %d, want %d", summary.Length, len(s))
+	}
+	if summary.SHA256 == "" {
+		t.Error("expected non-empty SHA256")
+	}
+	if summary.EncodingClass != "text" {
+		t.Errorf("EncodingClass = %q, want %q", summary.EncodingClass, "text")
+	}
+}
@@ -0,0 +1,62 @@
+package obfuscation
+
+import (
+	"github.com/ossf/package-analysis/internal/staticanalysis/obfuscation/stats"
+	"github.com/ossf/package-analysis/internal/staticanalysis/token"
+)
+
+// FileData holds the raw tokens extracted from a single source file, ready to be
+// passed to ComputeSignals.
+type FileData struct {
+	Identifiers    []token.Identifier
+	StringLiterals []token.String
+}
+
+/*
+FileSignals holds the full set of obfuscation-related signals computed for a
+single source file by ComputeSignals. Fields are independent of each other;
+a caller interested in a subset of signals can ignore the rest.
+*/
+type FileSignals struct {
+	StringLengths             map[int]int
+	StringEntropySummary      stats.SampleStatistics
+	CombinedStringEntropy     float64
+	IdentifierLengths         map[int]int
+	IdentifierEntropySummary  stats.SampleStatistics
+	CombinedIdentifierEntropy float64
+
+	// SuspiciousIdentifiers maps rule name to the identifiers that matched it.
+	SuspiciousIdentifiers map[string][]string
+	// Base64Strings holds string literals that look like base64-encoded data.
+	Base64Strings []string
+
+	// LikelyBenignStrings holds candidates that were removed from
+	// SuspiciousIdentifiers/Base64Strings by FalsePositiveFilter because they
+	// contain no digit or overlap with common English/programming vocabulary,
+	// and candidates removed from HexStrings, Base32Strings, URLEncodedStrings
+	// and UnicodeEscapeStrings because they overlap with that vocabulary.
+	LikelyBenignStrings []string
+
+	// StringZxcvbnEntropy and IdentifierZxcvbnEntropy summarise the
+	// zxcvbn-style guess entropy (see stringentropy.EstimateGuessEntropy) of
+	// string literals and identifiers respectively.
+	StringZxcvbnEntropy     stats.SampleStatistics
+	IdentifierZxcvbnEntropy stats.SampleStatistics
+
+	// HexStrings, Base32Strings, URLEncodedStrings and UnicodeEscapeStrings hold
+	// literals detected as carrying data encoded in the respective format.
+	HexStrings           []string
+	Base32Strings        []string
+	URLEncodedStrings    []string
+	UnicodeEscapeStrings []string
+
+	// LargeLiterals summarises literals too large to include in the entropy
+	// analysis above; see MaxLiteralSizeBytes. They're still sampled for the
+	// encoded-string signals above and the DecodedPayloads signal below.
+	LargeLiterals []LargeLiteralSummary
+
+	// DecodedPayloads holds the result of decoding and inspecting each
+	// detected base64, hex, base32 and URL-encoded candidate, including
+	// recursively decoded layers. Unicode-escape candidates are not included.
+	DecodedPayloads []DecodedPayload
+}
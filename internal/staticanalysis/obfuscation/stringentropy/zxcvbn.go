@@ -0,0 +1,265 @@
+package stringentropy
+
+import (
+	_ "embed"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ossf/package-analysis/internal/staticanalysis/obfuscation/fpfilter"
+)
+
+//go:embed data/common_passwords.txt
+var commonPasswordsRaw string
+
+var (
+	// english_words.txt is sorted alphabetically, not by frequency, so line
+	// position can't be used as a rank directly; shorter words are used as a
+	// rough stand-in for "more common" instead.
+	englishWordRank    = buildRankTable(sortedByLength(fpfilter.EnglishWords()))
+	commonPasswordRank = buildRankTable(fpfilter.SplitLines(commonPasswordsRaw))
+
+	qwertyGraph = buildQwertyGraph()
+)
+
+var (
+	allUpperPattern    = regexp.MustCompile(`^[A-Z]+$`)
+	capitalizedPattern = regexp.MustCompile(`^[A-Z][a-z]+$`)
+	endUpperPattern    = regexp.MustCompile(`^[a-z]+[A-Z]+$`)
+)
+
+// buildRankTable assigns each word a 1-based rank according to its position
+// in the list, used as a stand-in for guess rank as zxcvbn does with its own
+// frequency-ordered dictionaries. Callers must ensure words are already in
+// most-to-least-common order; common_passwords.txt is, but the embedded
+// english_words.txt is alphabetical and must be re-ordered first (see
+// sortedByLength).
+func buildRankTable(words []string) map[string]int {
+	ranks := make(map[string]int, len(words))
+	for i, w := range words {
+		ranks[strings.ToLower(w)] = i + 1
+	}
+	return ranks
+}
+
+// sortedByLength returns a copy of words ordered shortest-first, as a proxy
+// for frequency ordering when the source list is alphabetical rather than
+// frequency-ranked: shorter English words skew common ("city", "world") while
+// longer ones skew rare, so this avoids long early-alphabetically words like
+// "available" scoring as more guessable than short late ones like "world".
+func sortedByLength(words []string) []string {
+	sorted := make([]string, len(words))
+	copy(sorted, words)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) < len(sorted[j])
+	})
+	return sorted
+}
+
+func buildQwertyGraph() map[byte][]byte {
+	rows := []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+	graph := map[byte][]byte{}
+	for _, row := range rows {
+		for i := 0; i < len(row); i++ {
+			var neighbours []byte
+			if i > 0 {
+				neighbours = append(neighbours, row[i-1])
+			}
+			if i < len(row)-1 {
+				neighbours = append(neighbours, row[i+1])
+			}
+			graph[row[i]] = neighbours
+		}
+	}
+	return graph
+}
+
+// maxSegmentLength bounds how far back each position in the DP looks for a
+// single matcher segment. Real matches (dictionary words, keyboard walks,
+// repeats) are always short, so this keeps EstimateGuessEntropy roughly
+// O(n * maxSegmentLength) instead of O(n^2) segment evaluations, each of
+// which is itself O(segment length).
+const maxSegmentLength = 32
+
+/*
+EstimateGuessEntropy implements a zxcvbn-inspired guess-entropy estimate for
+a string, intended to complement plain Shannon entropy for spotting
+obfuscated identifiers and literals (e.g. "_0xabcd" vs "getUserName").
+
+It greedily segments s into the substrings that are cheapest to guess -
+dictionary words, repeated/sequential/keyboard-walk runs, or brute-forced
+character classes - via dynamic programming over all segmentations whose
+segments are at most maxSegmentLength bytes long, and sums the log2 guess
+count of each chosen segment.
+*/
+func EstimateGuessEntropy(s string) float64 {
+	n := len(s)
+	if n == 0 {
+		return 0
+	}
+
+	minEntropy := make([]float64, n+1)
+	for i := 1; i <= n; i++ {
+		best := math.Inf(1)
+		start := 0
+		if i-maxSegmentLength > start {
+			start = i - maxSegmentLength
+		}
+		for j := start; j < i; j++ {
+			candidate := minEntropy[j] + segmentEntropy(s[j:i])
+			if candidate < best {
+				best = candidate
+			}
+		}
+		minEntropy[i] = best
+	}
+	return minEntropy[n]
+}
+
+// segmentEntropy returns the lowest guess entropy among all matchers
+// applicable to sub, falling back to brute force if none else apply.
+func segmentEntropy(sub string) float64 {
+	best := bruteForceEntropy(sub)
+	if e, ok := dictionaryEntropy(sub); ok && e < best {
+		best = e
+	}
+	if e, ok := repeatEntropy(sub); ok && e < best {
+		best = e
+	}
+	if e, ok := sequenceEntropy(sub); ok && e < best {
+		best = e
+	}
+	if e, ok := keyboardEntropy(sub); ok && e < best {
+		best = e
+	}
+	return best
+}
+
+func capitalizationBonus(s string) float64 {
+	if allUpperPattern.MatchString(s) || capitalizedPattern.MatchString(s) || endUpperPattern.MatchString(s) {
+		return 1
+	}
+	return 0
+}
+
+func dictionaryEntropy(sub string) (float64, bool) {
+	lower := strings.ToLower(sub)
+	if rank, ok := englishWordRank[lower]; ok {
+		return math.Log2(float64(rank)) + capitalizationBonus(sub), true
+	}
+	if rank, ok := commonPasswordRank[lower]; ok {
+		return math.Log2(float64(rank)) + capitalizationBonus(sub), true
+	}
+	return 0, false
+}
+
+// repeatEntropy matches a run of the same character repeated length times.
+func repeatEntropy(sub string) (float64, bool) {
+	if len(sub) < 3 {
+		return 0, false
+	}
+	for i := 1; i < len(sub); i++ {
+		if sub[i] != sub[0] {
+			return 0, false
+		}
+	}
+	alphabetSize := charClassCardinality(sub[:1])
+	return math.Log2(float64(alphabetSize) * float64(len(sub))), true
+}
+
+// sequenceEntropy matches ascending/descending runs like "abcd" or "4321".
+func sequenceEntropy(sub string) (float64, bool) {
+	if len(sub) < 3 {
+		return 0, false
+	}
+	ascending, descending := true, true
+	for i := 1; i < len(sub); i++ {
+		diff := int(sub[i]) - int(sub[i-1])
+		if diff != 1 {
+			ascending = false
+		}
+		if diff != -1 {
+			descending = false
+		}
+	}
+	if !ascending && !descending {
+		return 0, false
+	}
+	return math.Log2(26 * float64(len(sub))), true
+}
+
+// keyboardEntropy matches runs where each character is adjacent to the
+// previous one on a QWERTY keyboard, e.g. "qwerty" or "asdf".
+func keyboardEntropy(sub string) (float64, bool) {
+	if len(sub) < 3 {
+		return 0, false
+	}
+	lower := strings.ToLower(sub)
+	for i := 1; i < len(lower); i++ {
+		neighbours, ok := qwertyGraph[lower[i-1]]
+		if !ok {
+			return 0, false
+		}
+		found := false
+		for _, n := range neighbours {
+			if n == lower[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, false
+		}
+	}
+
+	startingPositions := float64(len(qwertyGraph))
+	var totalDegree int
+	for _, neighbours := range qwertyGraph {
+		totalDegree += len(neighbours)
+	}
+	avgDegree := float64(totalDegree) / float64(len(qwertyGraph))
+
+	return math.Log2(startingPositions * math.Pow(avgDegree, float64(len(lower)-1))), true
+}
+
+// bruteForceEntropy is the fallback matcher: log2(cardinality^len), where
+// cardinality is the size of the character classes observed in sub.
+func bruteForceEntropy(sub string) float64 {
+	cardinality := charClassCardinality(sub)
+	return float64(len(sub)) * math.Log2(float64(cardinality))
+}
+
+func charClassCardinality(s string) int {
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+
+	cardinality := 0
+	if hasLower {
+		cardinality += 26
+	}
+	if hasUpper {
+		cardinality += 26
+	}
+	if hasDigit {
+		cardinality += 10
+	}
+	if hasSymbol {
+		cardinality += 33
+	}
+	if cardinality == 0 {
+		cardinality = 1
+	}
+	return cardinality
+}
@@ -0,0 +1,68 @@
+package stringentropy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateGuessEntropyEmptyString(t *testing.T) {
+	if got := EstimateGuessEntropy(""); got != 0 {
+		t.Errorf("EstimateGuessEntropy(\"\") = %v, want 0", got)
+	}
+}
+
+func TestEstimateGuessEntropySingleChar(t *testing.T) {
+	got := EstimateGuessEntropy("a")
+	want := math.Log2(26)
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("EstimateGuessEntropy(\"a\") = %v, want %v", got, want)
+	}
+}
+
+func TestEstimateGuessEntropyPatternedBeatsRandom(t *testing.T) {
+	// A genuinely random-looking string with no dictionary word, repeat,
+	// sequence or keyboard-walk substrings should score higher than strings
+	// that are fully explained by one of those cheaper matchers.
+	random := EstimateGuessEntropy("qlfy")
+
+	cases := map[string]string{
+		"repeated run":  "aaaa",
+		"sequence run":  "abcd",
+		"keyboard walk": "asdf",
+	}
+	for name, patterned := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := EstimateGuessEntropy(patterned)
+			if got >= random {
+				t.Errorf("EstimateGuessEntropy(%q) = %v, want less than random-string entropy %v", patterned, got, random)
+			}
+		})
+	}
+}
+
+func TestEstimateGuessEntropyDictionaryWordBeatsRandom(t *testing.T) {
+	// "about" is one of the shorter entries of the embedded english_words.txt
+	// wordlist, so it ranks low once re-ordered by length; its dictionary-match
+	// entropy (log2 of a small rank) should be far below that of a same-length
+	// string with no dictionary/repeat/sequence/keyboard-walk structure, which
+	// falls back to brute-force scoring.
+	word := EstimateGuessEntropy("about")
+	random := EstimateGuessEntropy("zqxvkj")
+
+	if word >= random {
+		t.Errorf("expected dictionary word entropy (%v) < random string entropy (%v)", word, random)
+	}
+}
+
+func TestEstimateGuessEntropyNonASCIIDoesNotPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("EstimateGuessEntropy panicked on non-ASCII input: %v", r)
+		}
+	}()
+
+	got := EstimateGuessEntropy("héllo_wörld")
+	if math.IsNaN(got) || math.IsInf(got, 0) {
+		t.Errorf("EstimateGuessEntropy on non-ASCII input returned non-finite value %v", got)
+	}
+}